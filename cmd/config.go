@@ -18,6 +18,7 @@ limitations under the License.
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 
@@ -87,6 +88,87 @@ var initCmd = &cobra.Command{
 	},
 }
 
+// setCmd represents the config set command
+var setCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "set a config value",
+	Long:  `set writes a single dotted key (e.g. server.host) to the config file, so config can be scripted in CI.`,
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		viper.Set(args[0], args[1])
+		if err := writeConfig(); err != nil {
+			fmt.Fprintf(os.Stderr, "While writing config: %s", err.Error())
+		}
+	},
+}
+
+// getCmd represents the config get command
+var getCmd = &cobra.Command{
+	Use:   "get [key]",
+	Short: "get a config value",
+	Long:  `get prints a single dotted key, or the whole config when no key is given.`,
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			out, err := json.MarshalIndent(viper.AllSettings(), "", "  ")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "While printing config: %s", err.Error())
+				return
+			}
+			fmt.Println(string(out))
+			return
+		}
+
+		fmt.Println(viper.Get(args[0]))
+	},
+}
+
+// useCmd represents the config use command
+var useCmd = &cobra.Command{
+	Use:   "use <profile>",
+	Short: "select which profiles.<profile> entry server settings are read from",
+	Long:  `use sets the active profile, so subsequent commands read server.* from profiles.<profile>.server.* instead of the top-level server block.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		viper.Set("profile", args[0])
+		if err := writeConfig(); err != nil {
+			fmt.Fprintf(os.Stderr, "While writing config: %s", err.Error())
+		}
+	},
+}
+
+// writeConfig saves viper's current settings back to the config file,
+// creating it at the default location the first time it's called.
+func writeConfig() error {
+	if err := viper.WriteConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
+			return viper.SafeWriteConfig()
+		}
+		return err
+	}
+	return nil
+}
+
+// activeProfile resolves which profiles.<profile> entry server settings
+// should be read from: the --profile flag if set, else the profile: key
+// left behind by `config use`, else "" for the top-level server block.
+func activeProfile(cmd *cobra.Command) string {
+	if p, err := cmd.Flags().GetString("profile"); err == nil && p != "" {
+		return p
+	}
+	return viper.GetString("profile")
+}
+
+// configKey prefixes key with profiles.<profile>. when a profile is active,
+// so callers can keep reading server.host etc. without caring whether
+// profiles are in use.
+func configKey(cmd *cobra.Command, key string) string {
+	if p := activeProfile(cmd); p != "" {
+		return "profiles." + p + "." + key
+	}
+	return key
+}
+
 func init() {
 	rootCmd.AddCommand(configCmd)
 
@@ -96,6 +178,9 @@ func init() {
 	// and all subcommands, e.g.:
 	// configCmd.PersistentFlags().String("foo", "", "A help for foo")
 	configCmd.AddCommand(initCmd)
+	configCmd.AddCommand(setCmd)
+	configCmd.AddCommand(getCmd)
+	configCmd.AddCommand(useCmd)
 
 	// Cobra supports local flags which will only run when this command
 	// is called directly, e.g.:
@@ -0,0 +1,100 @@
+/*
+Package cmd
+
+Copyright © 2021 Joe Glista <josephsglista@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ConnectionConfig carries the server and target database settings a Driver
+// needs to open a connection, independent of which backend is in use.
+type ConnectionConfig struct {
+	Host     string
+	Port     string
+	User     string
+	Pass     string
+	Database string
+}
+
+// Column describes a single column as reported by a driver, normalized to
+// roughly the shape of INFORMATION_SCHEMA.COLUMNS so templates can stay
+// driver-agnostic.
+type Column struct {
+	TableCatalog           string
+	TableSchema            string
+	TableName              string
+	ColumnName             string
+	OrdinalPosition        int
+	ColumnDefault          *string
+	IsNullable             string
+	DataType               string
+	CharacterMaximumLength *int
+	NumericPrecision       *int
+	NumericScale           *int
+}
+
+// ForeignKey describes a single-column foreign key: Column, in the table it
+// was looked up on, references RefColumn on RefTable.
+type ForeignKey struct {
+	Column    string `db:"column"`
+	RefTable  string `db:"ref_table"`
+	RefColumn string `db:"ref_column"`
+}
+
+// Driver is implemented by each supported database backend. It knows how to
+// connect to the server and how to describe the tables and columns it finds
+// there in terms sqlizer's generator can turn into Go types.
+type Driver interface {
+	Connect(cfg ConnectionConfig) (*sqlx.DB, error)
+	TableNames(db *sqlx.DB, schema string) ([]string, error)
+	ViewNames(db *sqlx.DB, schema string) ([]string, error)
+	Columns(db *sqlx.DB, schema, table string) ([]Column, error)
+	PrimaryKey(db *sqlx.DB, schema, table string) ([]string, error)
+	ForeignKeys(db *sqlx.DB, schema, table string) ([]ForeignKey, error)
+	UniqueColumns(db *sqlx.DB, schema, table string) ([]string, error)
+	TypeMap() map[string]string
+}
+
+var drivers = map[string]Driver{}
+
+// RegisterDriver makes a Driver available under name to the generate
+// command's --driver flag. It is expected to be called from each driver's
+// init function.
+func RegisterDriver(name string, d Driver) {
+	drivers[name] = d
+}
+
+// getDriver looks up a registered Driver by name, returning an error that
+// lists the known drivers if name isn't registered.
+func getDriver(name string) (Driver, error) {
+	d, ok := drivers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown driver %q (available: %s)", name, driverNames())
+	}
+	return d, nil
+}
+
+func driverNames() string {
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+	return fmt.Sprintf("%v", names)
+}
@@ -0,0 +1,226 @@
+/*
+Package cmd
+
+Copyright © 2021 Joe Glista <josephsglista@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	_ "github.com/denisenkom/go-mssqldb"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	mssqlVarChar  = "varchar"
+	mssqlNVarChar = "nvarchar"
+	mssqlChar     = "char"
+	mssqlInt      = "int"
+	mssqlFloat    = "float"
+	mssqlBit      = "bit"
+	mssqlTime     = "datetime"
+	mssqlBinary   = "binary"
+)
+
+// mssqlDriver talks to SQL Server over the go-mssqldb driver. It's the
+// original backend sqlizer shipped with, now just one of several Drivers.
+type mssqlDriver struct{}
+
+func init() {
+	RegisterDriver("mssql", &mssqlDriver{})
+}
+
+var mssqlTypeMap = map[string]string{
+	mssqlVarChar:  "string",
+	mssqlNVarChar: "string",
+	mssqlChar:     "string",
+	mssqlInt:      "int64",
+	mssqlFloat:    "float64",
+	mssqlBit:      "bool",
+	mssqlTime:     "time.Time",
+	mssqlBinary:   "[]byte",
+}
+
+func (d *mssqlDriver) Connect(cfg ConnectionConfig) (*sqlx.DB, error) {
+	if _, err := NewIdentifier(cfg.Database); err != nil {
+		return nil, fmt.Errorf("database: %w", err)
+	}
+
+	u := url.URL{
+		Scheme: "sqlserver",
+		User:   url.UserPassword(cfg.User, cfg.Pass),
+		Host:   fmt.Sprintf("%s:%s", cfg.Host, cfg.Port),
+	}
+	q := u.Query()
+	q.Set("database", cfg.Database)
+	u.RawQuery = q.Encode()
+
+	return sqlx.Connect("mssql", u.String())
+}
+
+func (d *mssqlDriver) TableNames(db *sqlx.DB, schema string) ([]string, error) {
+	var names []string
+	err := db.Select(
+		&names,
+		"SELECT TABLE_NAME FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA=? AND TABLE_TYPE='BASE TABLE'",
+		schema,
+	)
+	return names, err
+}
+
+func (d *mssqlDriver) ViewNames(db *sqlx.DB, schema string) ([]string, error) {
+	var names []string
+	err := db.Select(
+		&names,
+		"SELECT TABLE_NAME FROM INFORMATION_SCHEMA.VIEWS WHERE TABLE_SCHEMA=?",
+		schema,
+	)
+	return names, err
+}
+
+func (d *mssqlDriver) Columns(db *sqlx.DB, schema, table string) ([]Column, error) {
+	type mssqlColumnRow struct {
+		TableCatalog           string  `db:"TABLE_CATALOG"`
+		TableSchema            string  `db:"TABLE_SCHEMA"`
+		TableName              string  `db:"TABLE_NAME"`
+		ColumnName             string  `db:"COLUMN_NAME"`
+		OrdinalPosition        int     `db:"ORDINAL_POSITION"`
+		ColumnDefault          *string `db:"COLUMN_DEFAULT"`
+		IsNullable             string  `db:"IS_NULLABLE"`
+		DataType               string  `db:"DATA_TYPE"`
+		CharacterMaximumLength *int    `db:"CHARACTER_MAXIMUM_LENGTH"`
+		NumericPrecision       *int    `db:"NUMERIC_PRECISION"`
+		NumericScale           *int    `db:"NUMERIC_SCALE"`
+	}
+
+	var rows []mssqlColumnRow
+	err := db.Select(
+		&rows,
+		`
+			SELECT
+				TABLE_CATALOG,
+				TABLE_SCHEMA,
+				TABLE_NAME,
+				COLUMN_NAME,
+				ORDINAL_POSITION,
+				COLUMN_DEFAULT,
+				IS_NULLABLE,
+				DATA_TYPE,
+				CHARACTER_MAXIMUM_LENGTH,
+				NUMERIC_PRECISION,
+				NUMERIC_SCALE
+			FROM INFORMATION_SCHEMA.COLUMNS
+			WHERE TABLE_SCHEMA=? AND TABLE_NAME=?
+		`,
+		schema,
+		table,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	cols := make([]Column, 0, len(rows))
+	for _, r := range rows {
+		cols = append(cols, Column{
+			TableCatalog:           r.TableCatalog,
+			TableSchema:            r.TableSchema,
+			TableName:              r.TableName,
+			ColumnName:             r.ColumnName,
+			OrdinalPosition:        r.OrdinalPosition,
+			ColumnDefault:          r.ColumnDefault,
+			IsNullable:             r.IsNullable,
+			DataType:               r.DataType,
+			CharacterMaximumLength: r.CharacterMaximumLength,
+			NumericPrecision:       r.NumericPrecision,
+			NumericScale:           r.NumericScale,
+		})
+	}
+
+	if len(cols) == 0 {
+		return nil, errors.New("table does not exist")
+	}
+
+	return cols, nil
+}
+
+func (d *mssqlDriver) PrimaryKey(db *sqlx.DB, schema, table string) ([]string, error) {
+	var names []string
+	err := db.Select(
+		&names,
+		`
+			SELECT kcu.COLUMN_NAME
+			FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS tc
+			JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE kcu
+				ON tc.CONSTRAINT_NAME = kcu.CONSTRAINT_NAME
+				AND tc.TABLE_SCHEMA = kcu.TABLE_SCHEMA
+			WHERE tc.CONSTRAINT_TYPE = 'PRIMARY KEY'
+				AND tc.TABLE_SCHEMA = ?
+				AND tc.TABLE_NAME = ?
+			ORDER BY kcu.ORDINAL_POSITION
+		`,
+		schema,
+		table,
+	)
+	return names, err
+}
+
+func (d *mssqlDriver) ForeignKeys(db *sqlx.DB, schema, table string) ([]ForeignKey, error) {
+	var fks []ForeignKey
+	err := db.Select(
+		&fks,
+		`
+			SELECT
+				kcu.COLUMN_NAME AS [column],
+				kcu2.TABLE_NAME AS ref_table,
+				kcu2.COLUMN_NAME AS ref_column
+			FROM INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS rc
+			JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE kcu
+				ON rc.CONSTRAINT_NAME = kcu.CONSTRAINT_NAME
+			JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE kcu2
+				ON rc.UNIQUE_CONSTRAINT_NAME = kcu2.CONSTRAINT_NAME
+				AND kcu.ORDINAL_POSITION = kcu2.ORDINAL_POSITION
+			WHERE kcu.TABLE_SCHEMA = ? AND kcu.TABLE_NAME = ?
+		`,
+		schema,
+		table,
+	)
+	return fks, err
+}
+
+func (d *mssqlDriver) UniqueColumns(db *sqlx.DB, schema, table string) ([]string, error) {
+	var names []string
+	err := db.Select(
+		&names,
+		`
+			SELECT kcu.COLUMN_NAME
+			FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS tc
+			JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE kcu
+				ON tc.CONSTRAINT_NAME = kcu.CONSTRAINT_NAME
+			WHERE tc.CONSTRAINT_TYPE IN ('PRIMARY KEY', 'UNIQUE')
+				AND tc.TABLE_SCHEMA = ?
+				AND tc.TABLE_NAME = ?
+		`,
+		schema,
+		table,
+	)
+	return names, err
+}
+
+func (d *mssqlDriver) TypeMap() map[string]string {
+	return mssqlTypeMap
+}
@@ -0,0 +1,212 @@
+/*
+Package cmd
+
+Copyright © 2021 Joe Glista <josephsglista@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+)
+
+var mysqlTypeMap = map[string]string{
+	"varchar":    "string",
+	"char":       "string",
+	"text":       "string",
+	"tinytext":   "string",
+	"mediumtext": "string",
+	"longtext":   "string",
+	"int":        "int64",
+	"bigint":     "int64",
+	"smallint":   "int64",
+	"tinyint":    "int64",
+	"mediumint":  "int64",
+	"decimal":    "float64",
+	"float":      "float64",
+	"double":     "float64",
+	"date":       "time.Time",
+	"datetime":   "time.Time",
+	"timestamp":  "time.Time",
+	"blob":       "[]byte",
+	"varbinary":  "[]byte",
+	"json":       "json.RawMessage",
+}
+
+// mysqlDriver talks to MySQL/MariaDB over go-sql-driver/mysql.
+type mysqlDriver struct{}
+
+func init() {
+	RegisterDriver("mysql", &mysqlDriver{})
+}
+
+func (d *mysqlDriver) Connect(cfg ConnectionConfig) (*sqlx.DB, error) {
+	dsnCfg := mysql.NewConfig()
+	dsnCfg.Net = "tcp"
+	dsnCfg.Addr = fmt.Sprintf("%s:%s", cfg.Host, cfg.Port)
+	dsnCfg.User = cfg.User
+	dsnCfg.Passwd = cfg.Pass
+	dsnCfg.DBName = cfg.Database
+
+	return sqlx.Connect("mysql", dsnCfg.FormatDSN())
+}
+
+func (d *mysqlDriver) TableNames(db *sqlx.DB, schema string) ([]string, error) {
+	var names []string
+	err := db.Select(
+		&names,
+		"SELECT table_name FROM information_schema.tables WHERE table_schema=? AND table_type='BASE TABLE'",
+		schema,
+	)
+	return names, err
+}
+
+func (d *mysqlDriver) ViewNames(db *sqlx.DB, schema string) ([]string, error) {
+	var names []string
+	err := db.Select(
+		&names,
+		"SELECT table_name FROM information_schema.views WHERE table_schema=?",
+		schema,
+	)
+	return names, err
+}
+
+func (d *mysqlDriver) Columns(db *sqlx.DB, schema, table string) ([]Column, error) {
+	type mysqlColumnRow struct {
+		TableCatalog           string  `db:"table_catalog"`
+		TableSchema            string  `db:"table_schema"`
+		TableName              string  `db:"table_name"`
+		ColumnName             string  `db:"column_name"`
+		OrdinalPosition        int     `db:"ordinal_position"`
+		ColumnDefault          *string `db:"column_default"`
+		IsNullable             string  `db:"is_nullable"`
+		DataType               string  `db:"data_type"`
+		CharacterMaximumLength *int    `db:"character_maximum_length"`
+		NumericPrecision       *int    `db:"numeric_precision"`
+		NumericScale           *int    `db:"numeric_scale"`
+	}
+
+	var rows []mysqlColumnRow
+	err := db.Select(
+		&rows,
+		`
+			SELECT
+				table_catalog,
+				table_schema,
+				table_name,
+				column_name,
+				ordinal_position,
+				column_default,
+				is_nullable,
+				data_type,
+				character_maximum_length,
+				numeric_precision,
+				numeric_scale
+			FROM information_schema.columns
+			WHERE table_schema=? AND table_name=?
+		`,
+		schema,
+		table,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	cols := make([]Column, 0, len(rows))
+	for _, r := range rows {
+		cols = append(cols, Column{
+			TableCatalog:           r.TableCatalog,
+			TableSchema:            r.TableSchema,
+			TableName:              r.TableName,
+			ColumnName:             r.ColumnName,
+			OrdinalPosition:        r.OrdinalPosition,
+			ColumnDefault:          r.ColumnDefault,
+			IsNullable:             r.IsNullable,
+			DataType:               r.DataType,
+			CharacterMaximumLength: r.CharacterMaximumLength,
+			NumericPrecision:       r.NumericPrecision,
+			NumericScale:           r.NumericScale,
+		})
+	}
+
+	return cols, nil
+}
+
+func (d *mysqlDriver) PrimaryKey(db *sqlx.DB, schema, table string) ([]string, error) {
+	var names []string
+	err := db.Select(
+		&names,
+		`
+			SELECT kcu.column_name
+			FROM information_schema.table_constraints tc
+			JOIN information_schema.key_column_usage kcu
+				ON tc.constraint_name = kcu.constraint_name
+				AND tc.table_schema = kcu.table_schema
+			WHERE tc.constraint_type = 'PRIMARY KEY'
+				AND tc.table_schema = ?
+				AND tc.table_name = ?
+			ORDER BY kcu.ordinal_position
+		`,
+		schema,
+		table,
+	)
+	return names, err
+}
+
+func (d *mysqlDriver) ForeignKeys(db *sqlx.DB, schema, table string) ([]ForeignKey, error) {
+	var fks []ForeignKey
+	err := db.Select(
+		&fks,
+		`
+			SELECT
+				kcu.column_name AS ` + "`column`" + `,
+				kcu.referenced_table_name AS ref_table,
+				kcu.referenced_column_name AS ref_column
+			FROM information_schema.key_column_usage kcu
+			WHERE kcu.table_schema = ?
+				AND kcu.table_name = ?
+				AND kcu.referenced_table_name IS NOT NULL
+		`,
+		schema,
+		table,
+	)
+	return fks, err
+}
+
+func (d *mysqlDriver) UniqueColumns(db *sqlx.DB, schema, table string) ([]string, error) {
+	var names []string
+	err := db.Select(
+		&names,
+		`
+			SELECT kcu.column_name
+			FROM information_schema.table_constraints tc
+			JOIN information_schema.key_column_usage kcu
+				ON tc.constraint_name = kcu.constraint_name
+				AND tc.table_schema = kcu.table_schema
+			WHERE tc.constraint_type IN ('PRIMARY KEY', 'UNIQUE')
+				AND tc.table_schema = ?
+				AND tc.table_name = ?
+		`,
+		schema,
+		table,
+	)
+	return names, err
+}
+
+func (d *mysqlDriver) TypeMap() map[string]string {
+	return mysqlTypeMap
+}
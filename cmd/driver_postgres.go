@@ -0,0 +1,214 @@
+/*
+Package cmd
+
+Copyright © 2021 Joe Glista <josephsglista@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+)
+
+var postgresTypeMap = map[string]string{
+	"character varying":           "string",
+	"text":                        "string",
+	"varchar":                     "string",
+	"char":                        "string",
+	"integer":                     "int64",
+	"bigint":                      "int64",
+	"smallint":                    "int64",
+	"numeric":                     "float64",
+	"real":                        "float64",
+	"double precision":            "float64",
+	"boolean":                     "bool",
+	"date":                        "time.Time",
+	"timestamp":                   "time.Time",
+	"timestamptz":                 "time.Time",
+	"timestamp with time zone":    "time.Time",
+	"timestamp without time zone": "time.Time",
+	"bytea":                       "[]byte",
+	"jsonb":                       "json.RawMessage",
+	"json":                        "json.RawMessage",
+	"uuid":                        "uuid.UUID",
+}
+
+// postgresDriver talks to PostgreSQL over lib/pq.
+type postgresDriver struct{}
+
+func init() {
+	RegisterDriver("postgres", &postgresDriver{})
+}
+
+func (d *postgresDriver) Connect(cfg ConnectionConfig) (*sqlx.DB, error) {
+	dsn := fmt.Sprintf(
+		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+		cfg.Host, cfg.Port, cfg.User, cfg.Pass, cfg.Database,
+	)
+	return sqlx.Connect("postgres", dsn)
+}
+
+func (d *postgresDriver) TableNames(db *sqlx.DB, schema string) ([]string, error) {
+	var names []string
+	err := db.Select(
+		&names,
+		"SELECT table_name FROM information_schema.tables WHERE table_schema=$1 AND table_type='BASE TABLE'",
+		schema,
+	)
+	return names, err
+}
+
+func (d *postgresDriver) ViewNames(db *sqlx.DB, schema string) ([]string, error) {
+	var names []string
+	err := db.Select(
+		&names,
+		"SELECT table_name FROM information_schema.views WHERE table_schema=$1",
+		schema,
+	)
+	return names, err
+}
+
+func (d *postgresDriver) Columns(db *sqlx.DB, schema, table string) ([]Column, error) {
+	type postgresColumnRow struct {
+		TableCatalog           string  `db:"table_catalog"`
+		TableSchema            string  `db:"table_schema"`
+		TableName              string  `db:"table_name"`
+		ColumnName             string  `db:"column_name"`
+		OrdinalPosition        int     `db:"ordinal_position"`
+		ColumnDefault          *string `db:"column_default"`
+		IsNullable             string  `db:"is_nullable"`
+		DataType               string  `db:"data_type"`
+		CharacterMaximumLength *int    `db:"character_maximum_length"`
+		NumericPrecision       *int    `db:"numeric_precision"`
+		NumericScale           *int    `db:"numeric_scale"`
+	}
+
+	var rows []postgresColumnRow
+	err := db.Select(
+		&rows,
+		`
+			SELECT
+				table_catalog,
+				table_schema,
+				table_name,
+				column_name,
+				ordinal_position,
+				column_default,
+				is_nullable,
+				data_type,
+				character_maximum_length,
+				numeric_precision,
+				numeric_scale
+			FROM information_schema.columns
+			WHERE table_schema=$1 AND table_name=$2
+		`,
+		schema,
+		table,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	cols := make([]Column, 0, len(rows))
+	for _, r := range rows {
+		cols = append(cols, Column{
+			TableCatalog:           r.TableCatalog,
+			TableSchema:            r.TableSchema,
+			TableName:              r.TableName,
+			ColumnName:             r.ColumnName,
+			OrdinalPosition:        r.OrdinalPosition,
+			ColumnDefault:          r.ColumnDefault,
+			IsNullable:             r.IsNullable,
+			DataType:               r.DataType,
+			CharacterMaximumLength: r.CharacterMaximumLength,
+			NumericPrecision:       r.NumericPrecision,
+			NumericScale:           r.NumericScale,
+		})
+	}
+
+	return cols, nil
+}
+
+func (d *postgresDriver) PrimaryKey(db *sqlx.DB, schema, table string) ([]string, error) {
+	var names []string
+	err := db.Select(
+		&names,
+		`
+			SELECT kcu.column_name
+			FROM information_schema.table_constraints tc
+			JOIN information_schema.key_column_usage kcu
+				ON tc.constraint_name = kcu.constraint_name
+				AND tc.table_schema = kcu.table_schema
+			WHERE tc.constraint_type = 'PRIMARY KEY'
+				AND tc.table_schema = $1
+				AND tc.table_name = $2
+			ORDER BY kcu.ordinal_position
+		`,
+		schema,
+		table,
+	)
+	return names, err
+}
+
+func (d *postgresDriver) ForeignKeys(db *sqlx.DB, schema, table string) ([]ForeignKey, error) {
+	var fks []ForeignKey
+	err := db.Select(
+		&fks,
+		`
+			SELECT
+				kcu.column_name AS column,
+				kcu2.table_name AS ref_table,
+				kcu2.column_name AS ref_column
+			FROM information_schema.referential_constraints rc
+			JOIN information_schema.key_column_usage kcu
+				ON rc.constraint_name = kcu.constraint_name
+				AND rc.constraint_schema = kcu.constraint_schema
+			JOIN information_schema.key_column_usage kcu2
+				ON rc.unique_constraint_name = kcu2.constraint_name
+				AND rc.unique_constraint_schema = kcu2.constraint_schema
+				AND kcu.ordinal_position = kcu2.ordinal_position
+			WHERE kcu.table_schema = $1 AND kcu.table_name = $2
+		`,
+		schema,
+		table,
+	)
+	return fks, err
+}
+
+func (d *postgresDriver) UniqueColumns(db *sqlx.DB, schema, table string) ([]string, error) {
+	var names []string
+	err := db.Select(
+		&names,
+		`
+			SELECT kcu.column_name
+			FROM information_schema.table_constraints tc
+			JOIN information_schema.key_column_usage kcu
+				ON tc.constraint_name = kcu.constraint_name
+				AND tc.table_schema = kcu.table_schema
+			WHERE tc.constraint_type IN ('PRIMARY KEY', 'UNIQUE')
+				AND tc.table_schema = $1
+				AND tc.table_name = $2
+		`,
+		schema,
+		table,
+	)
+	return names, err
+}
+
+func (d *postgresDriver) TypeMap() map[string]string {
+	return postgresTypeMap
+}
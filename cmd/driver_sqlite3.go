@@ -0,0 +1,224 @@
+/*
+Package cmd
+
+Copyright © 2021 Joe Glista <josephsglista@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+var sqlite3TypeMap = map[string]string{
+	"text":     "string",
+	"varchar":  "string",
+	"char":     "string",
+	"integer":  "int64",
+	"int":      "int64",
+	"real":     "float64",
+	"double":   "float64",
+	"float":    "float64",
+	"boolean":  "bool",
+	"datetime": "time.Time",
+	"date":     "time.Time",
+	"blob":     "[]byte",
+}
+
+// sqlite3Driver talks to a SQLite file over mattn/go-sqlite3. SQLite has no
+// INFORMATION_SCHEMA, so it leans on sqlite_master and PRAGMA table_info
+// instead. cfg.Database is treated as the path to the database file, and
+// schema is ignored since SQLite doesn't support multiple schemas.
+type sqlite3Driver struct{}
+
+func init() {
+	RegisterDriver("sqlite3", &sqlite3Driver{})
+}
+
+func (d *sqlite3Driver) Connect(cfg ConnectionConfig) (*sqlx.DB, error) {
+	return sqlx.Connect("sqlite3", cfg.Database)
+}
+
+func (d *sqlite3Driver) TableNames(db *sqlx.DB, schema string) ([]string, error) {
+	var names []string
+	err := db.Select(
+		&names,
+		"SELECT name FROM sqlite_master WHERE type='table' AND name NOT LIKE 'sqlite_%'",
+	)
+	return names, err
+}
+
+func (d *sqlite3Driver) ViewNames(db *sqlx.DB, schema string) ([]string, error) {
+	var names []string
+	err := db.Select(
+		&names,
+		"SELECT name FROM sqlite_master WHERE type='view'",
+	)
+	return names, err
+}
+
+func (d *sqlite3Driver) Columns(db *sqlx.DB, schema, table string) ([]Column, error) {
+	type pragmaColumnRow struct {
+		Cid        int     `db:"cid"`
+		Name       string  `db:"name"`
+		Type       string  `db:"type"`
+		NotNull    int     `db:"notnull"`
+		DefaultVal *string `db:"dflt_value"`
+		Pk         int     `db:"pk"`
+	}
+
+	var rows []pragmaColumnRow
+	// PRAGMA doesn't accept bound parameters, so table (which may come straight
+	// from the -t flag) is quoted with quoteSqlite3Ident instead.
+	err := db.Select(&rows, fmt.Sprintf("PRAGMA table_info(%s)", quoteSqlite3Ident(table)))
+	if err != nil {
+		return nil, err
+	}
+
+	cols := make([]Column, 0, len(rows))
+	for i, r := range rows {
+		isNullable := "YES"
+		if r.NotNull == 1 {
+			isNullable = "NO"
+		}
+		cols = append(cols, Column{
+			TableName:       table,
+			ColumnName:      r.Name,
+			OrdinalPosition: i + 1,
+			ColumnDefault:   r.DefaultVal,
+			IsNullable:      isNullable,
+			DataType:        r.Type,
+		})
+	}
+
+	return cols, nil
+}
+
+func (d *sqlite3Driver) PrimaryKey(db *sqlx.DB, schema, table string) ([]string, error) {
+	type pragmaColumnRow struct {
+		Cid  int    `db:"cid"`
+		Name string `db:"name"`
+		Pk   int    `db:"pk"`
+	}
+
+	var rows []pragmaColumnRow
+	err := db.Select(&rows, fmt.Sprintf("PRAGMA table_info(%s)", quoteSqlite3Ident(table)))
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, 1)
+	for pos := 1; ; pos++ {
+		found := false
+		for _, r := range rows {
+			if r.Pk == pos {
+				names = append(names, r.Name)
+				found = true
+				break
+			}
+		}
+		if !found {
+			break
+		}
+	}
+
+	return names, nil
+}
+
+func (d *sqlite3Driver) ForeignKeys(db *sqlx.DB, schema, table string) ([]ForeignKey, error) {
+	type foreignKeyListRow struct {
+		ID       int    `db:"id"`
+		Seq      int    `db:"seq"`
+		Table    string `db:"table"`
+		From     string `db:"from"`
+		To       string `db:"to"`
+		OnUpdate string `db:"on_update"`
+		OnDelete string `db:"on_delete"`
+		Match    string `db:"match"`
+	}
+
+	var rows []foreignKeyListRow
+	err := db.Select(&rows, fmt.Sprintf("PRAGMA foreign_key_list(%s)", quoteSqlite3Ident(table)))
+	if err != nil {
+		return nil, err
+	}
+
+	fks := make([]ForeignKey, 0, len(rows))
+	for _, r := range rows {
+		fks = append(fks, ForeignKey{
+			Column:    r.From,
+			RefTable:  r.Table,
+			RefColumn: r.To,
+		})
+	}
+
+	return fks, nil
+}
+
+func (d *sqlite3Driver) UniqueColumns(db *sqlx.DB, schema, table string) ([]string, error) {
+	type indexListRow struct {
+		Seq     int    `db:"seq"`
+		Name    string `db:"name"`
+		Unique  int    `db:"unique"`
+		Origin  string `db:"origin"`
+		Partial int    `db:"partial"`
+	}
+	type indexInfoRow struct {
+		SeqNo int    `db:"seqno"`
+		Cid   int    `db:"cid"`
+		Name  string `db:"name"`
+	}
+
+	var indexes []indexListRow
+	err := db.Select(&indexes, fmt.Sprintf("PRAGMA index_list(%s)", quoteSqlite3Ident(table)))
+	if err != nil {
+		return nil, err
+	}
+
+	pk, err := d.PrimaryKey(db, schema, table)
+	if err != nil {
+		return nil, err
+	}
+	names := append([]string{}, pk...)
+
+	for _, idx := range indexes {
+		if idx.Unique != 1 {
+			continue
+		}
+
+		var cols []indexInfoRow
+		if err := db.Select(&cols, fmt.Sprintf("PRAGMA index_info(%s)", quoteSqlite3Ident(idx.Name))); err != nil {
+			return nil, err
+		}
+		if len(cols) == 1 {
+			names = append(names, cols[0].Name)
+		}
+	}
+
+	return names, nil
+}
+
+func (d *sqlite3Driver) TypeMap() map[string]string {
+	return sqlite3TypeMap
+}
+
+// quoteSqlite3Ident wraps a table name in double quotes for use inside a
+// PRAGMA statement, doubling any embedded quote.
+func quoteSqlite3Ident(ident string) string {
+	return `"` + strings.ReplaceAll(ident, `"`, `""`) + `"`
+}
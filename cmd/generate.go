@@ -20,82 +20,24 @@ package cmd
 import (
 	"bytes"
 	"embed"
-	"errors"
 	"fmt"
 	"go/format"
 	"io/ioutil"
-	"net/url"
 	"os"
 	"path"
+	"path/filepath"
 	"strings"
 	"text/template"
 
-	_ "github.com/denisenkom/go-mssqldb"
 	"github.com/jmoiron/sqlx"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"golang.org/x/tools/imports"
 )
 
-const (
-	mssqlVarChar  = "varchar"
-	mssqlNVarChar = "nvarchar"
-	mssqlChar     = "char"
-	mssqlInt      = "int"
-	mssqlFloat    = "float"
-	mssqlBit      = "bit"
-	mssqlTime     = "datetime"
-	mssqlBinary   = "binary"
-)
-
-type DatabaseExistsRow struct {
-	DatabaseExists bool `db:"DatabaseExists"`
-}
-
-type TableExistsRow struct {
-	TableExists bool `db:"TableExists"`
-}
-
-type Columns struct {
-	TableCatalog           string  `db:"TABLE_CATALOG"`
-	TableSchema            string  `db:"TABLE_SCHEMA"`
-	TableName              string  `db:"TABLE_NAME"`
-	ColumnName             string  `db:"COLUMN_NAME"`
-	OrdinalPosition        int     `db:"ORDINAL_POSITION"`
-	ColumnDefault          *string `db:"COLUMN_DEFAULT"`
-	IsNullable             string  `db:"IS_NULLABLE"`
-	DataType               string  `db:"DATA_TYPE"`
-	CharacterMaximumLength *int    `db:"CHARACTER_MAXIMUM_LENGTH"`
-	CharacterOctetLength   *int    `db:"CHARACTER_OCTET_LENGTH"`
-	NumericPrecision       *int    `db:"NUMERIC_PRECISION"`
-	NumericPrecisionRadix  *int    `db:"NUMERIC_PRECISION_RADIX"`
-	NumericScale           *int    `db:"NUMERIC_SCALE"`
-	DateTimePrecision      *int    `db:"DATETIME_PRECISION"`
-	CharacterSetCatalog    *string `db:"CHARACTER_SET_CATALOG"`
-	CharacterSetSchema     *string `db:"CHARACTER_SET_SCHEMA"`
-	CharacterSetName       *string `db:"CHARACTER_SET_NAME"`
-	CollationCatalog       *string `db:"COLLATION_CATALOG"`
-	CollationSchema        *string `db:"COLLATION_SCHEMA"`
-	CollationName          *string `db:"COLLATION_NAME"`
-	DomainCatalog          *string `db:"DOMAIN_CATALOG"`
-	DomainSchema           *string `db:"DOMAIN_SCHEMA"`
-	DomainName             *string `db:"DOMAIN_NAME"`
-}
-
 //go:embed templates/*
 var generateTmpl embed.FS
 
-var mssqlTypeMap = map[string]string{
-	mssqlVarChar:  "string",
-	mssqlNVarChar: "string",
-	mssqlChar:     "string",
-	mssqlInt:      "int64",
-	mssqlFloat:    "float64",
-	mssqlBit:      "bool",
-	mssqlTime:     "time.Time",
-	mssqlBinary:   "[]byte",
-}
-
 // generateCmd represents the generate command
 var generateCmd = &cobra.Command{
 	Use:   "generate",
@@ -104,165 +46,362 @@ var generateCmd = &cobra.Command{
 a table or a view. For example:
 
 sqlizer generate -d {YourDatabase} -t {YourTable}
+
+Omit -t (or pass --all) to generate every table in the database instead, one file per
+table under -o, sharing the package named by -p:
+
+sqlizer generate -d {YourDatabase} --all -o models -p models
 	`,
 	Run: func(cmd *cobra.Command, args []string) {
-		rows, err := readTable(cmd)
+		driver, err := getDriver(driverName(cmd))
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "While parsing database table: %s", err.Error())
+			fmt.Fprintf(os.Stderr, "While selecting driver: %s", err.Error())
 			return
 		}
 
-		fmtBytes, err := generateTypes(rows)
+		cfg := ConnectionConfig{
+			Host:     viper.GetString(configKey(cmd, "server.host")),
+			Port:     viper.GetString(configKey(cmd, "server.port")),
+			User:     viper.GetString(configKey(cmd, "server.user")),
+			Pass:     viper.GetString(configKey(cmd, "server.pass")),
+			Database: cmd.Flag("database").Value.String(),
+		}
+
+		db, err := driver.Connect(cfg)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "While generating code: %s", err.Error())
+			fmt.Fprintf(os.Stderr, "While connecting to database server: %s", err.Error())
 			return
 		}
+		defer db.Close()
+
+		schema := cmd.Flag("schema").Value.String()
+		if schema == "" {
+			schema = defaultSchema(driverName(cmd), cfg.Database)
+		}
 
-		err = writeTypes(cmd, fmtBytes)
+		tables, err := targetTables(cmd, driver, db, schema)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "While writing generated code: %s", err.Error())
+			fmt.Fprintf(os.Stderr, "While listing tables: %s", err.Error())
+			return
+		}
+
+		features, _ := cmd.Flags().GetStringSlice("features")
+
+		parsed := map[string]ParsedColumns{}
+		for _, table := range tables {
+			cols, err := driver.Columns(db, schema, table)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "While reading table %s: %s", table, err.Error())
+				return
+			}
+
+			pk, err := driver.PrimaryKey(db, schema, table)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "While reading primary key for %s: %s", table, err.Error())
+				return
+			}
+
+			pc := parseResults(cols, driver.TypeMap(), pk)
+			pc.PackageName = cmd.Flag("package").Value.String()
+			parsed[table] = pc
+		}
+
+		if err := attachRelations(db, driver, tables, parsed, schema); err != nil {
+			fmt.Fprintf(os.Stderr, "While resolving relationships: %s", err.Error())
 			return
 		}
+
+		templatesDir := cmd.Flag("templates").Value.String()
+		if templatesDir == "" {
+			templatesDir = viper.GetString("templates")
+		}
+
+		for _, table := range tables {
+			rawBytes, err := generateTypes(parsed[table], features, templatesDir)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "While generating code for %s: %s", table, err.Error())
+				return
+			}
+
+			if err := writeTypes(cmd, table, rawBytes); err != nil {
+				fmt.Fprintf(os.Stderr, "While writing generated code for %s: %s", table, err.Error())
+				return
+			}
+		}
 	},
 }
 
-func checkDatabaseExists(db *sqlx.DB, cmd *cobra.Command) (bool, error) {
-	var dbExists []DatabaseExistsRow
-
-	err := db.Select(
-		&dbExists,
-		`
-			IF DB_ID(?) IS NOT NULL
-			BEGIN
-				SELECT 1 AS DatabaseExists;
-			END
-			ELSE
-			BEGIN
-				SELECT 0 AS DatabaseExists;
-			END
-		`,
-		cmd.Flag("database").Value.String(),
-	)
-	if err != nil {
-		return false, err
-	}
-	if !dbExists[0].DatabaseExists {
-		return false, nil
+// attachRelations discovers each table's foreign keys and, for any that
+// reference another table in the current run, adds a BelongsTo relation on
+// the referencing (child) side and a HasOne/HasMany relation on the
+// referenced (parent) side, keyed off whether the FK column is unique.
+// References to tables outside this run are left off, since there would be
+// no generated type in the same package to point at.
+func attachRelations(db *sqlx.DB, driver Driver, tables []string, parsed map[string]ParsedColumns, schema string) error {
+	for _, table := range tables {
+		fks, err := driver.ForeignKeys(db, schema, table)
+		if err != nil {
+			return err
+		}
+
+		unique, err := driver.UniqueColumns(db, schema, table)
+		if err != nil {
+			return err
+		}
+		uniqueSet := make(map[string]bool, len(unique))
+		for _, u := range unique {
+			uniqueSet[strings.ToLower(u)] = true
+		}
+
+		for _, fk := range fks {
+			parentPC, ok := parsed[fk.RefTable]
+			if !ok {
+				continue
+			}
+			childPC := parsed[table]
+
+			belongsToName := relationFieldName(fk.Column, fk.RefTable)
+			childPC.Relations = append(childPC.Relations, Relation{
+				Kind:       "BelongsTo",
+				FieldName:  belongsToName,
+				TypeName:   "*" + fk.RefTable,
+				LoaderName: "Load" + belongsToName,
+				FKColumn:   fk.Column,
+				RefTable:   fk.RefTable,
+				RefColumn:  fk.RefColumn,
+			})
+			parsed[table] = childPC
+
+			parentSideName := relationFieldName(fk.Column, table)
+			if uniqueSet[strings.ToLower(fk.Column)] {
+				parentPC.Relations = append(parentPC.Relations, Relation{
+					Kind:       "HasOne",
+					FieldName:  parentSideName,
+					TypeName:   "*" + table,
+					LoaderName: "Load" + parentSideName,
+					FKColumn:   fk.Column,
+					RefTable:   table,
+					RefColumn:  fk.RefColumn,
+				})
+			} else {
+				parentPC.Relations = append(parentPC.Relations, Relation{
+					Kind:       "HasMany",
+					FieldName:  parentSideName,
+					TypeName:   "[]*" + table,
+					LoaderName: "Load" + parentSideName,
+					FKColumn:   fk.Column,
+					RefTable:   table,
+					RefColumn:  fk.RefColumn,
+				})
+			}
+			parsed[fk.RefTable] = parentPC
+		}
 	}
 
-	return true, nil
+	return nil
 }
 
-func checkTableExists(db *sqlx.DB, cmd *cobra.Command) (bool, error) {
-	var tableExists []TableExistsRow
-
-	err := db.Select(
-		&tableExists,
-		`
-			IF (
-				EXISTS (
-					SELECT *
-					FROM UserManagement.INFORMATION_SCHEMA.TABLES
-					WHERE TABLE_NAME = ?
-				)
-			)
-			BEGIN
-				SELECT 1 AS TableExists;
-			END
-			ELSE
-			BEGIN
-				SELECT 0 AS TableExists;
-			END
-		`,
-		cmd.Flag("table").Value.String(),
-	)
-	if err != nil {
-		return false, err
-	}
-	if !tableExists[0].TableExists {
-		return false, nil
+// relationFieldName picks the field/loader name for a relation traversing
+// fk's column to otherTable. Plain fk columns (e.g. user_id -> Users) reuse
+// the bare table name as before; anything else is prefixed with the column
+// (e.g. created_by_id -> CreatedByUsers) so a table with two FKs to the same
+// other table (orders.created_by / orders.assigned_to, both -> users)
+// doesn't generate two fields or loader methods with the same name.
+func relationFieldName(column, otherTable string) string {
+	base := fkFieldBase(column)
+	if strings.EqualFold(strings.TrimSuffix(base, "s"), strings.TrimSuffix(otherTable, "s")) {
+		return otherTable
 	}
+	return base + otherTable
+}
 
-	return true, nil
+// fkFieldBase turns a foreign key column name into a PascalCase prefix,
+// dropping a trailing "_id" (e.g. "created_by_id" -> "CreatedBy").
+func fkFieldBase(column string) string {
+	base := strings.TrimSuffix(strings.ToLower(column), "_id")
+	parts := strings.Split(base, "_")
+	for i, p := range parts {
+		if p == "" {
+			continue
+		}
+		parts[i] = strings.ToUpper(p[:1]) + p[1:]
+	}
+	return strings.Join(parts, "")
 }
 
-func readTable(cmd *cobra.Command) ([]Columns, error) {
-	u := url.URL{
-		Scheme: "sqlserver",
-		User:   url.UserPassword(viper.GetString("server.user"), viper.GetString("server.pass")),
-		Host:   fmt.Sprintf("%s:%s", viper.GetString("server.host"), viper.GetString("server.port")),
+// targetTables resolves the set of tables (and, with --views, views) that
+// generate should emit types for: either the single table named by -t, or
+// every table in the database when -t is omitted or --all is passed,
+// filtered by --include/--exclude glob patterns.
+func targetTables(cmd *cobra.Command, driver Driver, db *sqlx.DB, schema string) ([]string, error) {
+	all, _ := cmd.Flags().GetBool("all")
+	table := cmd.Flag("table").Value.String()
+
+	if table != "" && !all {
+		return []string{table}, nil
 	}
 
-	db, err := sqlx.Connect("mssql", u.String())
+	names, err := driver.TableNames(db, schema)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "While connecting to database server: %s", err.Error())
 		return nil, err
 	}
-	defer db.Close()
 
-	dbExists, err := checkDatabaseExists(db, cmd)
-	if err != nil {
-		return nil, err
+	if views, _ := cmd.Flags().GetBool("views"); views {
+		viewNames, err := driver.ViewNames(db, schema)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, viewNames...)
 	}
-	if !dbExists {
-		return nil, errors.New("databaseG does not exist")
+
+	include, _ := cmd.Flags().GetStringSlice("include")
+	exclude, _ := cmd.Flags().GetStringSlice("exclude")
+
+	filtered := names[:0]
+	for _, name := range names {
+		if len(include) > 0 && !matchesAny(include, name) {
+			continue
+		}
+		if matchesAny(exclude, name) {
+			continue
+		}
+		filtered = append(filtered, name)
 	}
 
-	tableExists, err := checkTableExists(db, cmd)
-	if err != nil {
-		return nil, err
+	return filtered, nil
+}
+
+// matchesAny reports whether name matches any of the given glob patterns.
+func matchesAny(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if ok, err := filepath.Match(p, name); err == nil && ok {
+			return true
+		}
 	}
-	if !tableExists {
-		return nil, errors.New("table does not exist")
+	return false
+}
+
+// driverName resolves the selected driver from the --driver flag, falling
+// back to the driver: key in viper config (flags still win).
+func driverName(cmd *cobra.Command) string {
+	if cmd.Flags().Changed("driver") {
+		return cmd.Flag("driver").Value.String()
 	}
+	if d := viper.GetString("driver"); d != "" {
+		return d
+	}
+	return cmd.Flag("driver").Value.String()
+}
 
-	t := cmd.Flag("table").Value.String()
-	var rows []Columns
-	// TODO: Don't use string building to inject the database name, this is a SQL injection risk.
-	err = db.Select(
-		&rows,
-		fmt.Sprintf("SELECT * FROM %s.INFORMATION_SCHEMA.COLUMNS WHERE TABLE_NAME=?", cmd.Flag("database").Value.String()),
-		t,
-	)
-	if err != nil {
-		return nil, err
+// defaultSchemas holds the schema generate falls back to per driver when
+// --schema is omitted, since "dbo" (MSSQL) and "public" (Postgres) aren't
+// interchangeable. MySQL has no entry here because its INFORMATION_SCHEMA
+// uses table_schema to mean the database itself; SQLite3 doesn't use the
+// concept at all.
+var defaultSchemas = map[string]string{
+	"mssql":    "dbo",
+	"postgres": "public",
+}
+
+// defaultSchema returns the schema generate should target for driver when
+// the user hasn't passed --schema. For mysql, where table_schema means the
+// database, that's database; other drivers fall back to defaultSchemas.
+func defaultSchema(driver, database string) string {
+	if driver == "mysql" {
+		return database
 	}
+	return defaultSchemas[driver]
+}
 
-	return rows, nil
+// featureTemplates maps a --features name to the template file that
+// implements it. struct.tmpl is always emitted first and isn't listed here.
+var featureTemplates = map[string]string{
+	"crud":    "crud.tmpl",
+	"finders": "finders.tmpl",
 }
 
-func generateTypes(rows []Columns) ([]byte, error) {
-	tmpl, err := template.New("generate.tmpl").Funcs(template.FuncMap{
-		"ToLower": strings.ToLower,
-	}).ParseFS(generateTmpl, "templates/generate.tmpl")
-	if err != nil {
-		return nil, err
+// generateTypes renders the selected templates for one table and returns
+// the raw, unformatted source. Callers are expected to run format.Source
+// after prepending any license header, since the header itself has to be
+// part of what gofmt sees.
+func generateTypes(parsed ParsedColumns, features []string, templatesDir string) ([]byte, error) {
+	tmplNames := []string{"struct.tmpl"}
+	for _, f := range features {
+		if name, ok := featureTemplates[f]; ok {
+			tmplNames = append(tmplNames, name)
+		}
+	}
+	if len(parsed.Relations) > 0 {
+		tmplNames = append(tmplNames, "relations.tmpl")
+	}
+
+	// crud.tmpl, finders.tmpl, and relations.tmpl all call into context and
+	// sqlx; struct.tmpl (always rendered first) emits the import block so
+	// the output is self-contained instead of relying on goimports to find
+	// sqlx from whatever directory -o happens to be.
+	if len(tmplNames) > 1 {
+		parsed.Imports = []string{"context", "github.com/jmoiron/sqlx"}
 	}
 
 	tmplBytes := []byte{}
 	tmplBuff := bytes.NewBuffer(tmplBytes)
-	err = tmpl.Execute(tmplBuff, parseResults(rows))
-	if err != nil {
-		return nil, err
+	for _, name := range tmplNames {
+		tmpl, err := loadTemplate(name, templatesDir)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := tmpl.Execute(tmplBuff, parsed); err != nil {
+			return nil, err
+		}
 	}
 
-	fmtBytes, err := format.Source(tmplBuff.Bytes())
-	if err != nil {
-		return nil, err
+	return tmplBuff.Bytes(), nil
+}
+
+// loadTemplate parses the named template, preferring a same-named file in
+// templatesDir (when set) and falling back to the embedded copy otherwise.
+// This lets a user override a single template, e.g. just struct.tmpl,
+// without having to supply the whole set.
+func loadTemplate(name, templatesDir string) (*template.Template, error) {
+	t := template.New(name).Funcs(template.FuncMap{
+		"ToLower": strings.ToLower,
+	})
+
+	if templatesDir != "" {
+		userPath := filepath.Join(templatesDir, name)
+		if data, err := ioutil.ReadFile(userPath); err == nil {
+			return t.Parse(string(data))
+		}
 	}
 
-	return fmtBytes, nil
+	return t.ParseFS(generateTmpl, "templates/"+name)
 }
 
-func writeTypes(cmd *cobra.Command, fileBytes []byte) error {
-	tLower := strings.ToLower(cmd.Flag("table").Value.String())
-	err := os.Mkdir(tLower, os.ModePerm)
-	if err != nil {
+func writeTypes(cmd *cobra.Command, table string, rawBytes []byte) error {
+	outDir := cmd.Flag("output").Value.String()
+	if err := os.MkdirAll(outDir, os.ModePerm); err != nil {
 		fmt.Fprintf(os.Stderr, "While writing directory: %s", err.Error())
 		return err
 	}
 
-	filePath := path.Join(tLower, tLower+".go")
+	header, err := resolveLicenseHeader()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "While resolving license header: %s", err.Error())
+		return err
+	}
+	if header != "" {
+		rawBytes = append([]byte(header+"\n"), rawBytes...)
+	}
+
+	fileBytes, err := format.Source(rawBytes)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "While formatting generated code: %s", err.Error())
+		return err
+	}
+
+	tLower := strings.ToLower(table)
+	filePath := path.Join(outDir, tLower+".go")
 	err = os.WriteFile(filePath, fileBytes, os.ModePerm)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "While writing generated code: %s", err.Error())
@@ -290,25 +429,52 @@ func writeTypes(cmd *cobra.Command, fileBytes []byte) error {
 }
 
 type ParsedColumns struct {
-	TableName  string
-	Attributes []Attribute
+	PackageName string
+	TableName   string
+	Attributes  []Attribute
+	PrimaryKeys []Attribute
+	Relations   []Relation
+	Imports     []string
 }
 
 type Attribute struct {
 	Name string
 	Type string
+	IsPK bool
+}
+
+// Relation describes a belongs-to, has-one, or has-many association
+// discovered from a foreign key, in terms of the field and loader method
+// generate should add to the owning table's type.
+type Relation struct {
+	Kind       string // "BelongsTo", "HasOne", or "HasMany"
+	FieldName  string
+	TypeName   string
+	LoaderName string
+	FKColumn   string
+	RefTable   string
+	RefColumn  string
 }
 
-func parseResults(c []Columns) ParsedColumns {
+func parseResults(c []Column, typeMap map[string]string, pk []string) ParsedColumns {
+	pkSet := make(map[string]bool, len(pk))
+	for _, name := range pk {
+		pkSet[strings.ToLower(name)] = true
+	}
+
 	pc := ParsedColumns{}
 
 	for _, col := range c {
 		pc.TableName = col.TableName
 
 		attr := Attribute{Name: col.ColumnName}
-		if val, ok := mssqlTypeMap[col.DataType]; ok {
+		if val, ok := typeMap[col.DataType]; ok {
 			attr.Type = val
 		}
+		if pkSet[strings.ToLower(col.ColumnName)] {
+			attr.IsPK = true
+			pc.PrimaryKeys = append(pc.PrimaryKeys, attr)
+		}
 		pc.Attributes = append(pc.Attributes, attr)
 	}
 
@@ -318,7 +484,16 @@ func parseResults(c []Columns) ParsedColumns {
 func init() {
 	rootCmd.AddCommand(generateCmd)
 	generateCmd.Flags().StringP("database", "d", "", "the database name")
-	generateCmd.Flags().StringP("table", "t", "", "the target table")
+	generateCmd.Flags().String("schema", "", "the schema to target, for databases with more than one (e.g. sales vs hr); defaults to dbo for mssql, public for postgres, and the database name for mysql")
+	generateCmd.Flags().StringP("table", "t", "", "the target table (omit, or pass --all, to generate every table)")
+	generateCmd.Flags().String("driver", "mssql", "the database driver to use (mssql, postgres, mysql, sqlite3)")
+	generateCmd.Flags().Bool("all", false, "generate types for every table in the database")
+	generateCmd.Flags().Bool("views", false, "also generate types for views")
+	generateCmd.Flags().StringSlice("include", nil, "glob patterns of table names to include")
+	generateCmd.Flags().StringSlice("exclude", nil, "glob patterns of table names to exclude")
+	generateCmd.Flags().StringP("output", "o", "models", "directory to write generated files to")
+	generateCmd.Flags().StringP("package", "p", "models", "package name for generated files")
+	generateCmd.Flags().StringSlice("features", []string{"struct", "crud", "finders"}, "comma-separated features to generate (struct, crud, finders)")
+	generateCmd.Flags().String("templates", "", "directory of user .tmpl files, falling back to the built-in templates per-file")
 	generateCmd.MarkFlagRequired("database")
-	generateCmd.MarkFlagRequired("table")
 }
@@ -0,0 +1,46 @@
+/*
+Package cmd
+
+Copyright © 2021 Joe Glista <josephsglista@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// maxIdentifierLength matches MSSQL's limit on the length of an identifier.
+const maxIdentifierLength = 128
+
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$#]*$`)
+
+// Identifier is a database, schema, or table name that has been validated
+// against MSSQL's identifier rules. mssqlDriver.Connect rejects cfg.Database
+// through NewIdentifier before it ever reaches the connection string, which
+// keeps a value like "mydb; DROP TABLE users" from ever reaching the server.
+type Identifier string
+
+// NewIdentifier validates name against MSSQL's identifier rules
+// ([A-Za-z_][A-Za-z0-9_$#]*, length <= 128) and returns it as an Identifier.
+func NewIdentifier(name string) (Identifier, error) {
+	if len(name) == 0 || len(name) > maxIdentifierLength {
+		return "", fmt.Errorf("identifier: %q must be between 1 and %d characters", name, maxIdentifierLength)
+	}
+	if !identifierPattern.MatchString(name) {
+		return "", fmt.Errorf("identifier: %q is not a valid identifier", name)
+	}
+	return Identifier(name), nil
+}
@@ -0,0 +1,62 @@
+/*
+Package cmd
+
+Copyright © 2021 Joe Glista <josephsglista@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewIdentifier(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		wantErr bool
+	}{
+		{name: "simple name", in: "dbo"},
+		{name: "underscore prefix", in: "_internal"},
+		{name: "digits, dollar, hash", in: "tbl_1$#"},
+		{name: "empty", in: "", wantErr: true},
+		{name: "leading digit", in: "1table", wantErr: true},
+		{name: "space", in: "my table", wantErr: true},
+		{name: "too long", in: strings.Repeat("a", maxIdentifierLength+1), wantErr: true},
+		{name: "max length ok", in: strings.Repeat("a", maxIdentifierLength)},
+		{name: "statement injection", in: "sales; DROP TABLE users;--", wantErr: true},
+		{name: "bracket escape injection", in: "mydb] EXEC xp_cmdshell('dir')--", wantErr: true},
+		{name: "union injection", in: "x' UNION SELECT password FROM users--", wantErr: true},
+		{name: "comment injection", in: "mydb/*", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := NewIdentifier(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("NewIdentifier(%q) = %q, want error", tt.in, id)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewIdentifier(%q) returned unexpected error: %s", tt.in, err.Error())
+			}
+			if string(id) != tt.in {
+				t.Fatalf("NewIdentifier(%q) = %q, want unchanged", tt.in, id)
+			}
+		})
+	}
+}
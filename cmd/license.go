@@ -0,0 +1,97 @@
+/*
+Package cmd
+
+Copyright © 2021 Joe Glista <josephsglista@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// namedLicenses holds the header comment sqlizer prepends to generated
+// files for each license shorthand accepted by the license: config key.
+var namedLicenses = map[string]string{
+	"apache": `// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+`,
+	"mit": `// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software.
+`,
+	"bsd-3": `// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the conditions of the BSD
+// 3-Clause License are met.
+`,
+}
+
+// resolveLicenseHeader turns the license: config value (a shorthand name, a
+// custom {header, text} pair, or nothing) into the comment block generate
+// should prepend to every emitted file. An empty string means no header.
+func resolveLicenseHeader() (string, error) {
+	raw := viper.Get("license")
+	if raw == nil {
+		return "", nil
+	}
+
+	switch v := raw.(type) {
+	case string:
+		return licenseByName(v)
+	case map[string]interface{}:
+		header, _ := v["header"].(string)
+		text, _ := v["text"].(string)
+		return customLicenseHeader(header, text), nil
+	default:
+		return "", fmt.Errorf("license: unsupported config value %v", raw)
+	}
+}
+
+func licenseByName(name string) (string, error) {
+	if name == "" || name == "none" {
+		return "", nil
+	}
+
+	header, ok := namedLicenses[name]
+	if !ok {
+		return "", fmt.Errorf("license: unknown shorthand %q", name)
+	}
+	return header, nil
+}
+
+func customLicenseHeader(header, text string) string {
+	if header == "" && text == "" {
+		return ""
+	}
+
+	out := ""
+	if header != "" {
+		out += "// " + header + "\n"
+	}
+	if text != "" {
+		out += "//\n"
+		for _, line := range strings.Split(text, "\n") {
+			out += "// " + line + "\n"
+		}
+	}
+	return out
+}
@@ -19,6 +19,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -56,6 +57,10 @@ func init() {
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
 	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+
+	// --profile selects which profiles.<profile> entry commands read server.* from;
+	// see config use and activeProfile.
+	rootCmd.PersistentFlags().String("profile", "", "the profiles.<profile> entry to read server settings from")
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -74,6 +79,10 @@ func initConfig() {
 		viper.SetConfigName(".sqlizer")
 	}
 
+	// Passwords don't need to sit on disk: SQLIZER_SERVER_HOST, SQLIZER_SERVER_PASS, etc.
+	// override the matching server.host / server.pass config keys.
+	viper.SetEnvPrefix("SQLIZER")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	viper.AutomaticEnv() // read in environment variables that match
 
 	// If a config file is found, read it in.